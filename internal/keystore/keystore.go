@@ -0,0 +1,259 @@
+// Package keystore loads wallet private keys without requiring them to sit
+// around on disk in plaintext. It accepts three formats, auto-detected per
+// file: newline-separated base58 secret keys (the original pk.txt format),
+// the standard Solana CLI JSON keypair (a 64-byte array), and a
+// scrypt+AES-GCM encrypted keystore file shaped like go-ethereum's
+// keystore, unlocked with a passphrase prompted without echo.
+package keystore
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/mr-tron/base58"
+	"golang.org/x/crypto/scrypt"
+	"golang.org/x/term"
+)
+
+const (
+	version    = 1
+	cipherName = "aes-256-gcm"
+	kdfName    = "scrypt"
+
+	scryptN     = 1 << 15
+	scryptR     = 8
+	scryptP     = 1
+	scryptDKLen = 32
+)
+
+// EncryptedKey is the on-disk shape of an encrypted keystore file.
+type EncryptedKey struct {
+	Version int          `json:"version"`
+	Address string       `json:"address"`
+	Crypto  cryptoParams `json:"crypto"`
+}
+
+type cryptoParams struct {
+	Cipher       string       `json:"cipher"`
+	CipherText   string       `json:"ciphertext"`
+	CipherParams cipherParams `json:"cipherparams"`
+	KDF          string       `json:"kdf"`
+	KDFParams    kdfParams    `json:"kdfparams"`
+}
+
+type cipherParams struct {
+	Nonce string `json:"nonce"`
+}
+
+type kdfParams struct {
+	N     int    `json:"n"`
+	R     int    `json:"r"`
+	P     int    `json:"p"`
+	DKLen int    `json:"dklen"`
+	Salt  string `json:"salt"`
+}
+
+// Load reads filename and returns every account it contains, auto-detecting
+// the format: an encrypted keystore or a Solana CLI keypair yields a single
+// account, a pk.txt-style file yields one account per base58 line.
+func Load(filename string) ([]types.Account, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	if enc, ok := parseEncryptedKey(data); ok {
+		passphrase, err := promptPassphrase(fmt.Sprintf("Passphrase for %s: ", filename))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read passphrase: %w", err)
+		}
+		account, err := Decrypt(enc, passphrase)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt keystore: %w", err)
+		}
+		return []types.Account{account}, nil
+	}
+
+	if account, ok := parseSolanaCLIKeypair(data); ok {
+		return []types.Account{account}, nil
+	}
+
+	accounts, err := parseBase58Lines(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("no wallets found in %s", filename)
+	}
+	return accounts, nil
+}
+
+func parseEncryptedKey(data []byte) (EncryptedKey, bool) {
+	var enc EncryptedKey
+	if err := json.Unmarshal(data, &enc); err != nil {
+		return EncryptedKey{}, false
+	}
+	if enc.Crypto.Cipher == "" || enc.Crypto.CipherText == "" {
+		return EncryptedKey{}, false
+	}
+	return enc, true
+}
+
+// parseSolanaCLIKeypair recognizes the `solana-keygen` JSON format: a bare
+// JSON array of the 64 secret key bytes.
+func parseSolanaCLIKeypair(data []byte) (types.Account, bool) {
+	var raw []byte
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return types.Account{}, false
+	}
+	account, err := types.AccountFromBytes(raw)
+	if err != nil {
+		return types.Account{}, false
+	}
+	return account, true
+}
+
+func parseBase58Lines(data []byte) ([]types.Account, error) {
+	var accounts []types.Account
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		account, err := types.AccountFromBase58(line)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse private key: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return accounts, nil
+}
+
+// Encrypt derives an AES-256 key from passphrase via scrypt and seals
+// account's private key with AES-GCM, returning a file ready to be
+// json.Marshal'd to disk.
+func Encrypt(account types.Account, passphrase string) (EncryptedKey, error) {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		return EncryptedKey{}, fmt.Errorf("failed to generate salt: %w", err)
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, scryptDKLen)
+	if err != nil {
+		return EncryptedKey{}, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return EncryptedKey{}, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return EncryptedKey{}, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return EncryptedKey{}, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	ciphertext := gcm.Seal(nil, nonce, account.PrivateKey, nil)
+
+	return EncryptedKey{
+		Version: version,
+		Address: account.PublicKey.ToBase58(),
+		Crypto: cryptoParams{
+			Cipher:       cipherName,
+			CipherText:   hex.EncodeToString(ciphertext),
+			CipherParams: cipherParams{Nonce: hex.EncodeToString(nonce)},
+			KDF:          kdfName,
+			KDFParams: kdfParams{
+				N:     scryptN,
+				R:     scryptR,
+				P:     scryptP,
+				DKLen: scryptDKLen,
+				Salt:  hex.EncodeToString(salt),
+			},
+		},
+	}, nil
+}
+
+// Decrypt reverses Encrypt, deriving the same AES key from passphrase and
+// opening the sealed private key.
+func Decrypt(enc EncryptedKey, passphrase string) (types.Account, error) {
+	if enc.Crypto.Cipher != cipherName || enc.Crypto.KDF != kdfName {
+		return types.Account{}, fmt.Errorf("unsupported keystore cipher/kdf: %s/%s", enc.Crypto.Cipher, enc.Crypto.KDF)
+	}
+
+	salt, err := hex.DecodeString(enc.Crypto.KDFParams.Salt)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to decode salt: %w", err)
+	}
+	nonce, err := hex.DecodeString(enc.Crypto.CipherParams.Nonce)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to decode nonce: %w", err)
+	}
+	ciphertext, err := hex.DecodeString(enc.Crypto.CipherText)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to decode ciphertext: %w", err)
+	}
+
+	params := enc.Crypto.KDFParams
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, params.DKLen)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to derive key: %w", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("failed to create GCM: %w", err)
+	}
+
+	plaintext, err := gcm.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return types.Account{}, fmt.Errorf("wrong passphrase or corrupted keystore: %w", err)
+	}
+
+	return types.AccountFromBytes(plaintext)
+}
+
+// promptPassphrase reads a passphrase from the terminal without echoing it.
+func promptPassphrase(prompt string) (string, error) {
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(int(syscall.Stdin))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+	return string(passphrase), nil
+}
+
+// PromptPassphrase reads a passphrase from the terminal without echoing it,
+// for use by the `keystore encrypt`/`keystore decrypt` subcommands.
+func PromptPassphrase(prompt string) (string, error) {
+	return promptPassphrase(prompt)
+}
+
+// EncodeBase58 renders account's private key the same way pk.txt expects
+// it, for use by the `keystore decrypt` subcommand.
+func EncodeBase58(account types.Account) string {
+	return base58.Encode(account.PrivateKey)
+}
@@ -0,0 +1,110 @@
+package keystore
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	account := types.NewAccount()
+
+	enc, err := Encrypt(account, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+
+	got, err := Decrypt(enc, "correct horse battery staple")
+	if err != nil {
+		t.Fatalf("Decrypt with correct passphrase failed: %v", err)
+	}
+	if got.PublicKey != account.PublicKey {
+		t.Fatalf("decrypted public key = %s, want %s", got.PublicKey.ToBase58(), account.PublicKey.ToBase58())
+	}
+
+	if _, err := Decrypt(enc, "wrong passphrase"); err == nil {
+		t.Fatal("Decrypt with wrong passphrase succeeded, want an error")
+	}
+}
+
+func TestLoadBase58Lines(t *testing.T) {
+	account := types.NewAccount()
+	path := writeTempFile(t, EncodeBase58(account)+"\n")
+
+	accounts, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].PublicKey != account.PublicKey {
+		t.Fatalf("Load returned %v, want [%s]", accounts, account.PublicKey.ToBase58())
+	}
+}
+
+func TestLoadSolanaCLIKeypair(t *testing.T) {
+	account := types.NewAccount()
+	// solana-keygen writes the secret key as an array of numbers; a []byte
+	// would marshal to a base64 string instead.
+	keypair := make([]int, len(account.PrivateKey))
+	for i, b := range account.PrivateKey {
+		keypair[i] = int(b)
+	}
+	data, err := json.Marshal(keypair)
+	if err != nil {
+		t.Fatalf("failed to marshal keypair: %v", err)
+	}
+	path := writeTempFile(t, string(data))
+
+	accounts, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(accounts) != 1 || accounts[0].PublicKey != account.PublicKey {
+		t.Fatalf("Load returned %v, want [%s]", accounts, account.PublicKey.ToBase58())
+	}
+}
+
+// TestLoadEncryptedKeystore exercises the same detect-then-decrypt path
+// Load takes for an encrypted keystore file, without going through Load
+// itself: Load prompts for a passphrase on the terminal, which isn't
+// available in a test.
+func TestLoadEncryptedKeystore(t *testing.T) {
+	account := types.NewAccount()
+	enc, err := Encrypt(account, "hunter2")
+	if err != nil {
+		t.Fatalf("Encrypt failed: %v", err)
+	}
+	data, err := json.Marshal(enc)
+	if err != nil {
+		t.Fatalf("failed to marshal keystore: %v", err)
+	}
+	path := writeTempFile(t, string(data))
+
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("failed to read temp file: %v", err)
+	}
+	loaded, ok := parseEncryptedKey(raw)
+	if !ok {
+		t.Fatal("parseEncryptedKey did not recognize an encrypted keystore file")
+	}
+
+	got, err := Decrypt(loaded, "hunter2")
+	if err != nil {
+		t.Fatalf("Decrypt failed: %v", err)
+	}
+	if got.PublicKey != account.PublicKey {
+		t.Fatalf("decrypted public key = %s, want %s", got.PublicKey.ToBase58(), account.PublicKey.ToBase58())
+	}
+}
+
+func writeTempFile(t *testing.T, contents string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "keys")
+	if err := os.WriteFile(path, []byte(contents), 0600); err != nil {
+		t.Fatalf("failed to write temp file: %v", err)
+	}
+	return path
+}
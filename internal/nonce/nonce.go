@@ -0,0 +1,104 @@
+// Package nonce manages durable nonce accounts so batch runs of thousands
+// of transfers don't fail when a fetched blockhash expires mid-run. A
+// durable nonce never expires until it is advanced, so it can stand in for
+// RecentBlockhash across an entire batch.
+package nonce
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/system"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+// seed is fixed so a wallet always derives the same nonce account address
+// via common.CreateWithSeed, instead of having to persist a generated
+// keypair alongside pk.txt.
+const seed = "sonic-sol2-nonce"
+
+// Create builds the two instructions needed to stand up a new durable
+// nonce account owned by the system program: CreateAccountWithSeed funds
+// and allocates it, InitializeNonceAccount stores the first nonce value
+// and sets the authority.
+func Create(ctx context.Context, rpcClient *client.Client, payer common.PublicKey, authority common.PublicKey) (common.PublicKey, []types.Instruction, error) {
+	nonceAccount := common.CreateWithSeed(payer, seed, common.SystemProgramID)
+
+	rentExemption, err := rpcClient.GetMinimumBalanceForRentExemption(ctx, system.NonceAccountSize)
+	if err != nil {
+		return common.PublicKey{}, nil, fmt.Errorf("failed to get rent exemption for nonce account: %w", err)
+	}
+
+	instructions := []types.Instruction{
+		system.CreateAccountWithSeed(system.CreateAccountWithSeedParam{
+			From:     payer,
+			New:      nonceAccount,
+			Base:     payer,
+			Owner:    common.SystemProgramID,
+			Seed:     seed,
+			Lamports: rentExemption,
+			Space:    system.NonceAccountSize,
+		}),
+		system.InitializeNonceAccount(system.InitializeNonceAccountParam{
+			Nonce: nonceAccount,
+			Auth:  authority,
+		}),
+	}
+
+	return nonceAccount, instructions, nil
+}
+
+// Address derives the nonce account address for payer the same way Create
+// does, without requiring a round trip to build the creation instructions.
+func Address(payer common.PublicKey) common.PublicKey {
+	return common.CreateWithSeed(payer, seed, common.SystemProgramID)
+}
+
+// GetNonce fetches and parses the nonce account, returning its currently
+// stored blockhash (the value transactions built against it must use as
+// RecentBlockhash).
+func GetNonce(ctx context.Context, rpcClient *client.Client, nonceAccount common.PublicKey) (common.PublicKey, error) {
+	info, err := rpcClient.GetAccountInfo(ctx, nonceAccount.ToBase58())
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to get nonce account info: %w", err)
+	}
+	state, err := system.NonceAccountDeserialize(info.Data)
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to parse nonce account: %w", err)
+	}
+	return state.Nonce, nil
+}
+
+// Advance builds the AdvanceNonceAccount instruction that must be the
+// first instruction of every transaction signed against a durable nonce,
+// so the stored nonce value changes and can't be replayed.
+func Advance(nonceAccount common.PublicKey, authority common.PublicKey) types.Instruction {
+	return system.AdvanceNonceAccount(system.AdvanceNonceAccountParam{
+		Nonce: nonceAccount,
+		Auth:  authority,
+	})
+}
+
+// Authorize builds the AuthorizeNonceAccount instruction that transfers
+// control of nonceAccount to newAuthority.
+func Authorize(nonceAccount common.PublicKey, authority common.PublicKey, newAuthority common.PublicKey) types.Instruction {
+	return system.AuthorizeNonceAccount(system.AuthorizeNonceAccountParam{
+		Nonce:   nonceAccount,
+		Auth:    authority,
+		NewAuth: newAuthority,
+	})
+}
+
+// Withdraw builds the WithdrawNonceAccount instruction that drains
+// lamports out of nonceAccount back to `to`. Withdrawing the full balance
+// closes the account.
+func Withdraw(nonceAccount common.PublicKey, authority common.PublicKey, to common.PublicKey, lamports uint64) types.Instruction {
+	return system.WithdrawNonceAccount(system.WithdrawNonceAccountParam{
+		Nonce:  nonceAccount,
+		Auth:   authority,
+		To:     to,
+		Amount: lamports,
+	})
+}
@@ -0,0 +1,282 @@
+// Package rpc wraps the blocto solana-go-sdk client with a WebSocket
+// signatureSubscribe confirmation path, so callers can wait for a
+// transaction to actually land instead of trusting a bare SendTransaction
+// response.
+package rpc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/client"
+	solanarpc "github.com/blocto/solana-go-sdk/rpc"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/gorilla/websocket"
+)
+
+// Status describes the terminal outcome of waiting for a submitted
+// transaction to confirm.
+type Status int
+
+const (
+	// Dropped means the transaction's blockhash expired before the
+	// cluster confirmed or finalized it.
+	Dropped Status = iota
+	// Confirmed means the cluster has seen the transaction land at
+	// least at the "confirmed" commitment level.
+	Confirmed
+	// Finalized means the transaction reached the "finalized" commitment
+	// level.
+	Finalized
+)
+
+func (s Status) String() string {
+	switch s {
+	case Confirmed:
+		return "confirmed"
+	case Finalized:
+		return "finalized"
+	default:
+		return "dropped"
+	}
+}
+
+// Result is the outcome of Client.SendAndConfirm.
+type Result struct {
+	Signature string
+	Status    Status
+	Slot      uint64
+	// Err carries the on-chain transaction error, if the cluster
+	// confirmed the signature but execution itself failed. SendAndConfirm
+	// reports such a failure as an error instead, so a Result it returns
+	// always has a nil Err.
+	Err any
+}
+
+// Client wraps a blocto client.Client for sends/queries and opens a dedicated
+// WebSocket connection to the cluster's pub/sub endpoint for
+// signatureSubscribe based confirmation.
+type Client struct {
+	http  *client.Client
+	wsURL string
+}
+
+// New wraps an existing blocto client with a WebSocket endpoint used for
+// confirmation subscriptions (e.g. "wss://devnet.sonic.game").
+func New(httpClient *client.Client, wsURL string) *Client {
+	return &Client{http: httpClient, wsURL: wsURL}
+}
+
+// HTTP returns the underlying blocto client for callers that need direct
+// access to RPC methods this wrapper doesn't cover.
+func (c *Client) HTTP() *client.Client {
+	return c.http
+}
+
+// SendAndConfirm submits tx and blocks until it reaches commitment, the
+// blockhash expires, or ctx is done. It replaces a bare SendTransaction
+// call followed by an optimistic log line: the caller only learns the
+// transaction landed once the cluster says so.
+func (c *Client) SendAndConfirm(ctx context.Context, tx types.Transaction, commitment Commitment) (*Result, error) {
+	signature, err := c.http.SendTransaction(ctx, tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send transaction: %w", err)
+	}
+
+	result, err := c.confirm(ctx, signature, tx.Message.RecentBlockHash, commitment)
+	if err != nil {
+		return nil, fmt.Errorf("failed to confirm %s: %w", signature, err)
+	}
+	if result.Err != nil {
+		return nil, fmt.Errorf("transaction %s landed but failed on-chain: %v", signature, result.Err)
+	}
+	return result, nil
+}
+
+// Commitment selects which signatureSubscribe commitment level to wait
+// for before SendAndConfirm returns.
+type Commitment string
+
+const (
+	CommitmentConfirmed Commitment = "confirmed"
+	CommitmentFinalized Commitment = "finalized"
+)
+
+// confirm opens a signatureSubscribe subscription for signature and waits
+// for either a notification, blockhash expiry, or context cancellation.
+func (c *Client) confirm(ctx context.Context, signature string, blockhash string, commitment Commitment) (*Result, error) {
+	conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.wsURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial %s: %w", c.wsURL, err)
+	}
+	defer conn.Close()
+
+	subID, err := subscribeSignature(conn, signature, commitment)
+	if err != nil {
+		return nil, err
+	}
+	defer unsubscribeSignature(conn, subID)
+
+	// signatureSubscribe only pushes on a future status *change*; if the
+	// transaction already reached commitment in the gap between
+	// SendTransaction and the subscription taking effect, no notification
+	// will ever arrive for it. Catch that here instead of waiting for the
+	// expiry ticker to wrongly report it Dropped.
+	if result, err := c.checkSignatureStatus(ctx, signature, commitment); err == nil && result != nil {
+		return result, nil
+	}
+
+	notifications := make(chan signatureNotification, 1)
+	readErrs := make(chan error, 1)
+	go readNotifications(conn, subID, notifications, readErrs)
+
+	expiryTicker := time.NewTicker(5 * time.Second)
+	defer expiryTicker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err := <-readErrs:
+			return nil, err
+		case n := <-notifications:
+			status := Confirmed
+			if commitment == CommitmentFinalized {
+				status = Finalized
+			}
+			return &Result{
+				Signature: signature,
+				Status:    status,
+				Slot:      n.Slot,
+				Err:       n.Err,
+			}, nil
+		case <-expiryTicker.C:
+			if result, err := c.checkSignatureStatus(ctx, signature, commitment); err == nil && result != nil {
+				return result, nil
+			}
+			valid, err := c.http.IsBlockhashValid(ctx, blockhash)
+			if err != nil {
+				continue
+			}
+			if !valid {
+				return &Result{Signature: signature, Status: Dropped}, nil
+			}
+		}
+	}
+}
+
+// commitmentRank orders commitment levels so checkSignatureStatus can tell
+// whether an observed status satisfies the level a caller asked for.
+var commitmentRank = map[solanarpc.Commitment]int{
+	solanarpc.CommitmentProcessed: 0,
+	solanarpc.CommitmentConfirmed: 1,
+	solanarpc.CommitmentFinalized: 2,
+}
+
+// checkSignatureStatus polls getSignatureStatuses directly, returning a
+// Result if signature has already reached commitment and nil if it hasn't
+// (or the lookup itself failed), in which case the caller should keep
+// waiting on the subscription.
+func (c *Client) checkSignatureStatus(ctx context.Context, signature string, commitment Commitment) (*Result, error) {
+	status, err := c.http.GetSignatureStatus(ctx, signature)
+	if err != nil {
+		return nil, err
+	}
+	if status == nil || status.ConfirmationStatus == nil {
+		return nil, nil
+	}
+	if commitmentRank[*status.ConfirmationStatus] < commitmentRank[solanarpc.Commitment(commitment)] {
+		return nil, nil
+	}
+
+	result := Confirmed
+	if *status.ConfirmationStatus == solanarpc.CommitmentFinalized {
+		result = Finalized
+	}
+	return &Result{Signature: signature, Status: result, Slot: status.Slot, Err: status.Err}, nil
+}
+
+type jsonRpcRequest struct {
+	JsonRpc string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  []any  `json:"params"`
+}
+
+type subscribeResponse struct {
+	Result int `json:"result"`
+}
+
+func subscribeSignature(conn *websocket.Conn, signature string, commitment Commitment) (int, error) {
+	req := jsonRpcRequest{
+		JsonRpc: "2.0",
+		ID:      1,
+		Method:  "signatureSubscribe",
+		Params: []any{
+			signature,
+			map[string]string{"commitment": string(commitment)},
+		},
+	}
+	if err := conn.WriteJSON(req); err != nil {
+		return 0, fmt.Errorf("failed to send signatureSubscribe: %w", err)
+	}
+
+	var resp subscribeResponse
+	if err := conn.ReadJSON(&resp); err != nil {
+		return 0, fmt.Errorf("failed to read signatureSubscribe ack: %w", err)
+	}
+	return resp.Result, nil
+}
+
+func unsubscribeSignature(conn *websocket.Conn, subID int) {
+	req := jsonRpcRequest{
+		JsonRpc: "2.0",
+		ID:      2,
+		Method:  "signatureUnsubscribe",
+		Params:  []any{subID},
+	}
+	_ = conn.WriteJSON(req)
+}
+
+type signatureNotification struct {
+	Slot uint64
+	Err  any
+}
+
+type signatureSubscribeNotice struct {
+	Params struct {
+		Subscription int `json:"subscription"`
+		Result       struct {
+			Context struct {
+				Slot uint64 `json:"slot"`
+			} `json:"context"`
+			Value struct {
+				Err any `json:"err"`
+			} `json:"value"`
+		} `json:"result"`
+	} `json:"params"`
+}
+
+func readNotifications(conn *websocket.Conn, subID int, notifications chan<- signatureNotification, errs chan<- error) {
+	for {
+		var notice signatureSubscribeNotice
+		_, raw, err := conn.ReadMessage()
+		if err != nil {
+			errs <- fmt.Errorf("websocket read failed: %w", err)
+			return
+		}
+		if err := json.Unmarshal(raw, &notice); err != nil {
+			continue
+		}
+		if notice.Params.Subscription != subID {
+			continue
+		}
+		notifications <- signatureNotification{
+			Slot: notice.Params.Result.Context.Slot,
+			Err:  notice.Params.Result.Value.Err,
+		}
+		return
+	}
+}
@@ -0,0 +1,262 @@
+package tasks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/types"
+	"github.com/rs/zerolog/log"
+
+	sonicrpc "sonic-sol-blocto/internal/rpc"
+	"sonic-sol-blocto/internal/tx"
+)
+
+const (
+	txMilestoneURL = "https://odyssey-api-beta.sonic.game/user/transactions/state/daily"
+	claimRewardURL = "https://odyssey-api-beta.sonic.game/user/transactions/rewards/claim"
+	checkInURL     = "https://odyssey-api-beta.sonic.game/user/check-in"
+	openBoxURL     = "https://odyssey-api-beta.sonic.game/user/rewards/mystery-box/open"
+
+	sonicUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 10_15_7) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/97.0.4692.71 Safari/537.36"
+
+	defaultRetryDelay  = 2 * time.Second
+	defaultSendTimeout = 60 * time.Second
+
+	// alreadyClaimedCode is the "code" field the claim-reward endpoint
+	// returns, with an HTTP 200, when the stage was already claimed.
+	alreadyClaimedCode = 100015
+)
+
+func init() {
+	Register("transfer", newTransferTask)
+	Register("wait", newWaitTask)
+	Register("claim", newClaimTask)
+	Register("checkIn", newCheckInTask)
+	Register("openBox", newOpenBoxTask)
+}
+
+// transferTask sends count SOL transfers from the session wallet to fresh,
+// one-off keypairs, each for a random amount between min and max SOL.
+type transferTask struct {
+	count    int
+	min, max float64
+}
+
+func newTransferTask(config map[string]any) (Task, error) {
+	count, err := configInt(config, "count", 1)
+	if err != nil {
+		return nil, err
+	}
+	min, err := configFloat(config, "min", 0.001)
+	if err != nil {
+		return nil, err
+	}
+	max, err := configFloat(config, "max", min)
+	if err != nil {
+		return nil, err
+	}
+	return &transferTask{count: count, min: min, max: max}, nil
+}
+
+func (t *transferTask) Name() string {
+	return fmt.Sprintf("transfer{count:%d,min:%g,max:%g}", t.count, t.min, t.max)
+}
+
+func (t *transferTask) Run(ctx context.Context, sess *Session) error {
+	for i := 0; i < t.count; i++ {
+		to := types.NewAccount().PublicKey
+		randomAmount := t.min + rand.Float64()*(t.max-t.min)
+		lamports := uint64(randomAmount * 1_000_000_000)
+
+		for {
+			transaction, err := tx.BuildTransferTx(ctx, sess.RPCClient, sess.Wallet, to, lamports, tx.Options{
+				WritableAccounts: []common.PublicKey{sess.Wallet.PublicKey, to},
+			})
+			if err != nil {
+				return fmt.Errorf("failed to build transfer %d/%d: %w", i+1, t.count, err)
+			}
+
+			sendCtx, cancel := context.WithTimeout(ctx, defaultSendTimeout)
+			result, err := sess.ConfirmClient.SendAndConfirm(sendCtx, transaction, sonicrpc.CommitmentConfirmed)
+			cancel()
+			if err != nil {
+				time.Sleep(defaultRetryDelay)
+				continue
+			}
+			if result.Status == sonicrpc.Dropped {
+				continue
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// waitTask pauses the run for a fixed duration, e.g. to let a milestone
+// finish indexing before the next task queries it.
+type waitTask struct {
+	duration time.Duration
+}
+
+func newWaitTask(config map[string]any) (Task, error) {
+	duration, err := configDuration(config, "duration", 10*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	return &waitTask{duration: duration}, nil
+}
+
+func (t *waitTask) Name() string {
+	return fmt.Sprintf("wait{%s}", t.duration)
+}
+
+func (t *waitTask) Run(ctx context.Context, sess *Session) error {
+	select {
+	case <-time.After(t.duration):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// claimTask claims each of a wallet's milestone reward stages in order.
+type claimTask struct {
+	stages []int
+}
+
+func newClaimTask(config map[string]any) (Task, error) {
+	stages, err := configIntSlice(config, "stages")
+	if err != nil {
+		return nil, err
+	}
+	return &claimTask{stages: stages}, nil
+}
+
+func (t *claimTask) Name() string {
+	return fmt.Sprintf("claim{stages:%v}", t.stages)
+}
+
+func (t *claimTask) Run(ctx context.Context, sess *Session) error {
+	for _, stage := range t.stages {
+		body, err := json.Marshal(map[string]int{"stage": stage})
+		if err != nil {
+			return fmt.Errorf("failed to marshal claim payload: %w", err)
+		}
+		respBody, err := postSonic(ctx, claimRewardURL, sess.AuthToken, body)
+		if err != nil {
+			return fmt.Errorf("failed to claim stage %d: %w", stage, err)
+		}
+
+		var result struct {
+			Code   float64 `json:"code"`
+			Status string  `json:"status"`
+		}
+		if err := json.Unmarshal(respBody, &result); err != nil {
+			log.Warn().Int("stage", stage).Str("response", string(respBody)).Msg("Failed to parse claim response")
+			continue
+		}
+		switch {
+		case result.Code == alreadyClaimedCode:
+			log.Info().Int("stage", stage).Msg("Stage already claimed")
+		case result.Status == "success":
+			log.Info().Int("stage", stage).Msg("Claimed reward stage")
+		default:
+			log.Warn().Int("stage", stage).Str("response", string(respBody)).Msg("Claim did not report success")
+		}
+	}
+	return nil
+}
+
+// checkInTask performs the Sonic Odyssey daily check-in.
+type checkInTask struct{}
+
+func newCheckInTask(config map[string]any) (Task, error) {
+	return &checkInTask{}, nil
+}
+
+func (t *checkInTask) Name() string {
+	return "checkIn"
+}
+
+func (t *checkInTask) Run(ctx context.Context, sess *Session) error {
+	respBody, err := postSonic(ctx, checkInURL, sess.AuthToken, nil)
+	if err != nil {
+		return err
+	}
+	logActionResult("checkIn", respBody)
+	return nil
+}
+
+// openBoxTask opens a wallet's earned mystery box reward.
+type openBoxTask struct{}
+
+func newOpenBoxTask(config map[string]any) (Task, error) {
+	return &openBoxTask{}, nil
+}
+
+func (t *openBoxTask) Name() string {
+	return "openBox"
+}
+
+func (t *openBoxTask) Run(ctx context.Context, sess *Session) error {
+	respBody, err := postSonic(ctx, openBoxURL, sess.AuthToken, nil)
+	if err != nil {
+		return err
+	}
+	logActionResult("openBox", respBody)
+	return nil
+}
+
+// logActionResult logs whether a 200-OK Sonic Odyssey response actually
+// reported success, so an application-level failure returned alongside an
+// HTTP 200 isn't silently treated the same as a real success.
+func logActionResult(action string, respBody []byte) {
+	var result struct {
+		Status string `json:"status"`
+	}
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		log.Warn().Str("action", action).Str("response", string(respBody)).Msg("Failed to parse response")
+		return
+	}
+	if result.Status == "success" {
+		log.Info().Str("action", action).Msg("Succeeded")
+	} else {
+		log.Warn().Str("action", action).Str("response", string(respBody)).Msg("Did not report success")
+	}
+}
+
+// postSonic POSTs body (or an empty body when nil) to the odyssey-api-beta
+// endpoint url, authenticated with authToken, and returns the raw response
+// body for callers that need to inspect it.
+func postSonic(ctx context.Context, url, authToken string, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", authToken)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", sonicUserAgent)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return respBody, fmt.Errorf("unexpected status %d: %s", resp.StatusCode, respBody)
+	}
+	return respBody, nil
+}
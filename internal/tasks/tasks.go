@@ -0,0 +1,192 @@
+// Package tasks turns a Sonic Odyssey run into a data-driven pipeline
+// instead of a hardcoded quest flow. A run is a list of Tasks loaded from
+// a YAML or JSON task file, each carrying its own config, executed in
+// order against a shared Session. Adding a new Sonic endpoint means
+// registering a new task type, not touching the run loop.
+package tasks
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/types"
+	"gopkg.in/yaml.v3"
+
+	sonicrpc "sonic-sol-blocto/internal/rpc"
+)
+
+// Session carries everything a Task needs to act on behalf of one wallet:
+// the auth token obtained from the Sonic challenge/authorize flow, the RPC
+// clients used to build and confirm transactions, and the wallet itself.
+type Session struct {
+	RPCClient     *client.Client
+	ConfirmClient *sonicrpc.Client
+	Wallet        types.Account
+	AuthToken     string
+}
+
+// Task is one step of a run. Name identifies it in logs; Run executes it
+// against sess, returning an error that aborts the rest of the run.
+type Task interface {
+	Name() string
+	Run(ctx context.Context, sess *Session) error
+}
+
+// Factory builds a Task from its raw config block (the task file entry
+// minus the "type" discriminator).
+type Factory func(config map[string]any) (Task, error)
+
+var factories = map[string]Factory{}
+
+// Register adds a task type to the registry under name, so task files can
+// reference it. Call from an init() in the file defining the task type.
+func Register(name string, factory Factory) {
+	factories[name] = factory
+}
+
+// taskDef is one entry of a task file: {"type": "transfer", ...config}.
+type taskDef struct {
+	Type   string
+	Config map[string]any
+}
+
+// LoadFile reads a YAML or JSON task file (by extension) and builds the
+// Task list it describes, in order.
+func LoadFile(path string) ([]Task, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read task file: %w", err)
+	}
+
+	defs, err := parseTaskDefs(path, data)
+	if err != nil {
+		return nil, err
+	}
+
+	tasks := make([]Task, 0, len(defs))
+	for i, def := range defs {
+		factory, ok := factories[def.Type]
+		if !ok {
+			return nil, fmt.Errorf("task %d: unknown task type %q", i, def.Type)
+		}
+		task, err := factory(def.Config)
+		if err != nil {
+			return nil, fmt.Errorf("task %d (%s): %w", i, def.Type, err)
+		}
+		tasks = append(tasks, task)
+	}
+	return tasks, nil
+}
+
+func parseTaskDefs(path string, data []byte) ([]taskDef, error) {
+	var raw []map[string]any
+	if strings.HasSuffix(path, ".json") {
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse task file as JSON: %w", err)
+		}
+	} else {
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, fmt.Errorf("failed to parse task file as YAML: %w", err)
+		}
+	}
+
+	defs := make([]taskDef, 0, len(raw))
+	for i, entry := range raw {
+		rawType, ok := entry["type"].(string)
+		if !ok || rawType == "" {
+			return nil, fmt.Errorf("task %d: missing \"type\"", i)
+		}
+		config := make(map[string]any, len(entry)-1)
+		for k, v := range entry {
+			if k != "type" {
+				config[k] = v
+			}
+		}
+		defs = append(defs, taskDef{Type: rawType, Config: config})
+	}
+	return defs, nil
+}
+
+// configFloat reads a numeric field from a task's config, accepting either
+// the float64 JSON decodes to or the int/float64 YAML decodes to.
+func configFloat(config map[string]any, key string, fallback float64) (float64, error) {
+	v, ok := config[key]
+	if !ok {
+		return fallback, nil
+	}
+	switch n := v.(type) {
+	case float64:
+		return n, nil
+	case int:
+		return float64(n), nil
+	default:
+		return 0, fmt.Errorf("%q must be a number, got %T", key, v)
+	}
+}
+
+// configInt is configFloat truncated to an int, for fields like "count".
+func configInt(config map[string]any, key string, fallback int) (int, error) {
+	f, err := configFloat(config, key, float64(fallback))
+	if err != nil {
+		return 0, err
+	}
+	return int(f), nil
+}
+
+// configIntSlice reads a list of numbers from a task's config, e.g.
+// "stages: [1, 2, 3]".
+func configIntSlice(config map[string]any, key string) ([]int, error) {
+	v, ok := config[key]
+	if !ok {
+		return nil, fmt.Errorf("%q is required", key)
+	}
+	raw, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("%q must be a list, got %T", key, v)
+	}
+	ints := make([]int, 0, len(raw))
+	for _, item := range raw {
+		switch n := item.(type) {
+		case float64:
+			ints = append(ints, int(n))
+		case int:
+			ints = append(ints, n)
+		default:
+			return nil, fmt.Errorf("%q entries must be numbers, got %T", key, item)
+		}
+	}
+	return ints, nil
+}
+
+// configDuration reads a duration string (e.g. "10s") from a task's
+// config.
+func configDuration(config map[string]any, key string, fallback time.Duration) (time.Duration, error) {
+	v, ok := config[key]
+	if !ok {
+		return fallback, nil
+	}
+	s, ok := v.(string)
+	if !ok {
+		return 0, fmt.Errorf("%q must be a duration string, got %T", key, v)
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a valid duration: %w", key, err)
+	}
+	return d, nil
+}
+
+// Run executes tasks in order against sess, stopping at the first error.
+func Run(ctx context.Context, sess *Session, tasks []Task) error {
+	for _, task := range tasks {
+		if err := task.Run(ctx, sess); err != nil {
+			return fmt.Errorf("task %q: %w", task.Name(), err)
+		}
+	}
+	return nil
+}
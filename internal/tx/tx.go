@@ -0,0 +1,173 @@
+// Package tx builds Sonic/Solana transfer transactions with priority-fee
+// aware compute-budget instructions, so transfers land reliably even when
+// the cluster is congested instead of relying on blind SendTransaction
+// retries.
+package tx
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/blocto/solana-go-sdk/client"
+	"github.com/blocto/solana-go-sdk/common"
+	"github.com/blocto/solana-go-sdk/program/compute_budget"
+	"github.com/blocto/solana-go-sdk/program/system"
+	"github.com/blocto/solana-go-sdk/types"
+
+	"sonic-sol-blocto/internal/nonce"
+)
+
+const (
+	// defaultComputeUnitLimit is the unit budget for a plain SOL transfer.
+	// A system transfer consumes a few hundred CUs; we pad generously so a
+	// stale estimate never causes the transaction to run out of budget.
+	defaultComputeUnitLimit = 1_000
+	// defaultPriorityFeePercentile is the percentile of recently observed
+	// prioritization fees used when the caller doesn't override it.
+	defaultPriorityFeePercentile = 75
+	// minMicroLamportPrice is the price floor applied even when the
+	// cluster reports no recent prioritization fees at all.
+	minMicroLamportPrice = 1
+)
+
+// Options controls how BuildTransferTx prices and sizes the compute-budget
+// instructions it prepends to the transfer.
+type Options struct {
+	// ComputeUnitLimit is the compute unit limit requested via
+	// ComputeBudgetProgram.SetComputeUnitLimit. Defaults to
+	// defaultComputeUnitLimit when zero.
+	ComputeUnitLimit uint32
+	// PriorityFeePercentile selects which percentile of the recent
+	// prioritization fee sample to pay (e.g. 75 for p75). Defaults to
+	// defaultPriorityFeePercentile when zero.
+	PriorityFeePercentile int
+	// MaxMicroLamportPrice caps the computed compute-unit price so a single
+	// spike in the fee sample can't blow out the fee budget. Zero means
+	// unbounded.
+	MaxMicroLamportPrice uint64
+	// WritableAccounts are the accounts passed to getRecentPrioritizationFees
+	// to narrow the fee sample to the accounts this transaction touches. If
+	// empty, the fee sample is cluster-wide.
+	WritableAccounts []common.PublicKey
+	// NonceAccount, when set, builds the transaction against a durable
+	// nonce instead of GetLatestBlockhash: an AdvanceNonceAccount
+	// instruction is prepended and the nonce's stored blockhash is used
+	// as RecentBlockhash, so the transaction never expires mid-batch.
+	// NonceAuthority must be set whenever NonceAccount is.
+	NonceAccount   common.PublicKey
+	NonceAuthority common.PublicKey
+}
+
+func (o Options) usesNonce() bool {
+	return o.NonceAccount != common.PublicKey{}
+}
+
+func (o Options) computeUnitLimit() uint32 {
+	if o.ComputeUnitLimit == 0 {
+		return defaultComputeUnitLimit
+	}
+	return o.ComputeUnitLimit
+}
+
+func (o Options) percentile() int {
+	if o.PriorityFeePercentile == 0 {
+		return defaultPriorityFeePercentile
+	}
+	return o.PriorityFeePercentile
+}
+
+// BuildTransferTx builds a signed SOL transfer transaction from `from` to
+// `to`, prepending ComputeBudgetProgram instructions that request a tight
+// compute unit limit and a dynamic priority fee sampled from recent
+// prioritization fees on the cluster.
+func BuildTransferTx(
+	ctx context.Context,
+	rpcClient *client.Client,
+	from types.Account,
+	to common.PublicKey,
+	lamports uint64,
+	opts Options,
+) (types.Transaction, error) {
+	microLamportPrice, err := recentPriorityFee(ctx, rpcClient, opts)
+	if err != nil {
+		return types.Transaction{}, fmt.Errorf("failed to sample recent prioritization fees: %w", err)
+	}
+
+	var recentBlockhash string
+	instructions := []types.Instruction{}
+
+	if opts.usesNonce() {
+		nonceValue, err := nonce.GetNonce(ctx, rpcClient, opts.NonceAccount)
+		if err != nil {
+			return types.Transaction{}, fmt.Errorf("failed to get durable nonce: %w", err)
+		}
+		recentBlockhash = nonceValue.ToBase58()
+		instructions = append(instructions, nonce.Advance(opts.NonceAccount, opts.NonceAuthority))
+	} else {
+		blockhash, err := rpcClient.GetLatestBlockhash(ctx)
+		if err != nil {
+			return types.Transaction{}, fmt.Errorf("failed to get latest blockhash: %w", err)
+		}
+		recentBlockhash = blockhash.Blockhash
+	}
+
+	instructions = append(instructions,
+		compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{
+			Units: opts.computeUnitLimit(),
+		}),
+		compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{
+			MicroLamports: microLamportPrice,
+		}),
+		system.Transfer(system.TransferParam{
+			From:   from.PublicKey,
+			To:     to,
+			Amount: lamports,
+		}),
+	)
+
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:        from.PublicKey,
+		RecentBlockhash: recentBlockhash,
+		Instructions:    instructions,
+	})
+
+	transaction, err := types.NewTransaction(types.NewTransactionParam{
+		Message: message,
+		Signers: []types.Account{from},
+	})
+	if err != nil {
+		return types.Transaction{}, fmt.Errorf("failed to build transaction: %w", err)
+	}
+
+	return transaction, nil
+}
+
+// recentPriorityFee samples getRecentPrioritizationFees and returns the
+// micro-lamport price at opts' target percentile, floored at
+// minMicroLamportPrice.
+func recentPriorityFee(ctx context.Context, rpcClient *client.Client, opts Options) (uint64, error) {
+	fees, err := rpcClient.GetRecentPrioritizationFees(ctx, opts.WritableAccounts)
+	if err != nil {
+		return 0, err
+	}
+	if len(fees) == 0 {
+		return minMicroLamportPrice, nil
+	}
+
+	samples := make([]uint64, len(fees))
+	for i, fee := range fees {
+		samples[i] = fee.PrioritizationFee
+	}
+	sort.Slice(samples, func(i, j int) bool { return samples[i] < samples[j] })
+
+	idx := (len(samples) - 1) * opts.percentile() / 100
+	price := samples[idx]
+	if price < minMicroLamportPrice {
+		price = minMicroLamportPrice
+	}
+	if opts.MaxMicroLamportPrice != 0 && price > opts.MaxMicroLamportPrice {
+		price = opts.MaxMicroLamportPrice
+	}
+	return price, nil
+}
@@ -0,0 +1,62 @@
+package txfmt
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/blocto/solana-go-sdk/program/compute_budget"
+	"github.com/blocto/solana-go-sdk/program/system"
+	"github.com/blocto/solana-go-sdk/types"
+)
+
+func TestEncodeTree(t *testing.T) {
+	from := types.NewAccount()
+	to := types.NewAccount().PublicKey
+
+	const (
+		lamports      = 1_000_000
+		computeUnits  = 1_000
+		microLamports = 5
+	)
+
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:        from.PublicKey,
+		RecentBlockhash: "11111111111111111111111111111111",
+		Instructions: []types.Instruction{
+			compute_budget.SetComputeUnitLimit(compute_budget.SetComputeUnitLimitParam{Units: computeUnits}),
+			compute_budget.SetComputeUnitPrice(compute_budget.SetComputeUnitPriceParam{MicroLamports: microLamports}),
+			system.Transfer(system.TransferParam{From: from.PublicKey, To: to, Amount: lamports}),
+		},
+	})
+
+	transaction, err := types.NewTransaction(types.NewTransactionParam{
+		Message: message,
+		Signers: []types.Account{from},
+	})
+	if err != nil {
+		t.Fatalf("failed to build transaction: %v", err)
+	}
+
+	tree := EncodeTree(transaction)
+
+	for _, want := range []string{
+		fmt.Sprintf("SetComputeUnitLimit{units: %d}", computeUnits),
+		fmt.Sprintf("SetComputeUnitPrice{microLamports: %d}", microLamports),
+		fmt.Sprintf("Transfer{lamports: %d}", lamports),
+	} {
+		if !strings.Contains(tree, want) {
+			t.Errorf("EncodeTree output missing %q, got:\n%s", want, tree)
+		}
+	}
+
+	feePayerLine := fmt.Sprintf("%s (signer=true, writable=true)", from.PublicKey.ToBase58())
+	if !strings.Contains(tree, feePayerLine) {
+		t.Errorf("EncodeTree output missing fee payer account flags %q, got:\n%s", feePayerLine, tree)
+	}
+
+	toLine := fmt.Sprintf("%s (signer=false, writable=true)", to.ToBase58())
+	if !strings.Contains(tree, toLine) {
+		t.Errorf("EncodeTree output missing recipient account flags %q, got:\n%s", toLine, tree)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/ed25519"
 	"encoding/base64"
 	"encoding/json"
+	"flag"
 	"fmt"
 	"io/ioutil"
 	"math/rand"
@@ -19,19 +20,26 @@ import (
 
 	"github.com/blocto/solana-go-sdk/client"
 	"github.com/blocto/solana-go-sdk/common"
-	"github.com/blocto/solana-go-sdk/program/system"
-	"github.com/blocto/solana-go-sdk/rpc"
 	"github.com/blocto/solana-go-sdk/types"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+
+	"sonic-sol-blocto/internal/keystore"
+	"sonic-sol-blocto/internal/nonce"
+	sonicrpc "sonic-sol-blocto/internal/rpc"
+	"sonic-sol-blocto/internal/tasks"
+	"sonic-sol-blocto/internal/tx"
+	"sonic-sol-blocto/internal/txfmt"
 )
 
 const (
-	delayRetry   = 2 * time.Second
-	minSolAmount = 0.001
-	maxSolAmount = 0.01
-	challengeURL = "https://odyssey-api-beta.sonic.game/auth/sonic/challenge"
-	authorizeURL = "https://odyssey-api-beta.sonic.game/auth/sonic/authorize"
+	delayRetry     = 2 * time.Second
+	minSolAmount   = 0.001
+	maxSolAmount   = 0.01
+	challengeURL   = "https://odyssey-api-beta.sonic.game/auth/sonic/challenge"
+	authorizeURL   = "https://odyssey-api-beta.sonic.game/auth/sonic/authorize"
+	sonicWsURL     = "wss://devnet.sonic.game"
+	confirmTimeout = 60 * time.Second
 )
 
 var headers = map[string]string{
@@ -58,38 +66,7 @@ func initLogger() {
 	log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr, TimeFormat: time.RFC3339})
 }
 
-func readPrivateKeys(filename string) ([]string, error) {
-	file, err := os.Open(filename)
-	if err != nil {
-		return nil, err
-	}
-	defer file.Close()
-
-	var privateKeys []string
-	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		privateKey := strings.TrimSpace(scanner.Text())
-		if privateKey != "" {
-			privateKeys = append(privateKeys, privateKey)
-		}
-	}
-	if err := scanner.Err(); err != nil {
-		return nil, err
-	}
-
-	if len(privateKeys) == 0 {
-		return nil, fmt.Errorf("No wallets found in pk.txt file")
-	}
-
-	return privateKeys, nil
-}
-
-func getToken(privateKey string) (string, error) {
-	account, err := types.AccountFromBase58(privateKey)
-	if err != nil {
-		return "", fmt.Errorf("failed to get account from private key: %w", err)
-	}
-
+func getToken(account types.Account) (string, error) {
 	challengeReq, err := http.NewRequest("GET", challengeURL, nil)
 	if err != nil {
 		return "", fmt.Errorf("failed to create challenge request: %w", err)
@@ -264,16 +241,186 @@ func claimReward(authToken string, stage int) {
 	}
 }
 
+// ensureNonceAccount returns the durable nonce account address for wallet,
+// creating and initializing it on-chain first if it doesn't exist yet.
+func ensureNonceAccount(rpcClient *client.Client, confirmClient *sonicrpc.Client, wallet types.Account) (common.PublicKey, error) {
+	nonceAccount := nonce.Address(wallet.PublicKey)
+
+	info, err := rpcClient.GetAccountInfo(context.TODO(), nonceAccount.ToBase58())
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to look up nonce account: %w", err)
+	}
+	if info.Lamports > 0 {
+		return nonceAccount, nil
+	}
+
+	_, instructions, err := nonce.Create(context.TODO(), rpcClient, wallet.PublicKey, wallet.PublicKey)
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to build nonce account creation instructions: %w", err)
+	}
+
+	blockhash, err := rpcClient.GetLatestBlockhash(context.TODO())
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to get latest blockhash: %w", err)
+	}
+
+	message := types.NewMessage(types.NewMessageParam{
+		FeePayer:        wallet.PublicKey,
+		RecentBlockhash: blockhash.Blockhash,
+		Instructions:    instructions,
+	})
+
+	transaction, err := types.NewTransaction(types.NewTransactionParam{
+		Message: message,
+		Signers: []types.Account{wallet},
+	})
+	if err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to build nonce account creation transaction: %w", err)
+	}
+
+	confirmCtx, cancel := context.WithTimeout(context.Background(), confirmTimeout)
+	defer cancel()
+	if _, err := confirmClient.SendAndConfirm(confirmCtx, transaction, sonicrpc.CommitmentConfirmed); err != nil {
+		return common.PublicKey{}, fmt.Errorf("failed to create nonce account: %w", err)
+	}
+
+	log.Info().
+		Str("wallet", wallet.PublicKey.ToBase58()).
+		Str("nonce account", nonceAccount.ToBase58()).
+		Msg("Created durable nonce account")
+
+	return nonceAccount, nil
+}
+
+// runKeystoreCommand implements the `keystore encrypt`/`keystore decrypt`
+// subcommands: encrypt turns a plaintext pk.txt-style or Solana CLI keypair
+// file into a scrypt+AES-GCM keystore file, decrypt reverses it.
+func runKeystoreCommand(args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("usage: keystore <encrypt|decrypt> <input> <output>")
+	}
+	action, input, output := args[0], args[1], args[2]
+
+	switch action {
+	case "encrypt":
+		accounts, err := keystore.Load(input)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", input, err)
+		}
+		if len(accounts) != 1 {
+			return fmt.Errorf("%s must contain exactly one private key, got %d", input, len(accounts))
+		}
+
+		passphrase, err := keystore.PromptPassphrase("New passphrase: ")
+		if err != nil {
+			return fmt.Errorf("failed to read passphrase: %w", err)
+		}
+
+		enc, err := keystore.Encrypt(accounts[0], passphrase)
+		if err != nil {
+			return fmt.Errorf("failed to encrypt: %w", err)
+		}
+
+		data, err := json.MarshalIndent(enc, "", "  ")
+		if err != nil {
+			return fmt.Errorf("failed to marshal keystore: %w", err)
+		}
+		if err := os.WriteFile(output, data, 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+
+		log.Info().Str("wallet", enc.Address).Str("file", output).Msg("Wrote encrypted keystore")
+		return nil
+
+	case "decrypt":
+		accounts, err := keystore.Load(input)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", input, err)
+		}
+
+		var lines []string
+		for _, account := range accounts {
+			lines = append(lines, keystore.EncodeBase58(account))
+		}
+		if err := os.WriteFile(output, []byte(strings.Join(lines, "\n")+"\n"), 0600); err != nil {
+			return fmt.Errorf("failed to write %s: %w", output, err)
+		}
+
+		log.Info().Int("wallets", len(accounts)).Str("file", output).Msg("Wrote decrypted private keys")
+		return nil
+
+	default:
+		return fmt.Errorf("unknown keystore command %q, want encrypt or decrypt", action)
+	}
+}
+
+// runTasks executes the task file at path once per wallet in accounts,
+// wiring each wallet into its own Session so tasks can build/send
+// transactions and hit authenticated Sonic Odyssey endpoints.
+func runTasks(rpcClient *client.Client, confirmClient *sonicrpc.Client, accounts []types.Account, path string) error {
+	loaded, err := tasks.LoadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to load task file: %w", err)
+	}
+
+	for _, wallet := range accounts {
+		authToken, err := getToken(wallet)
+		if err != nil {
+			log.Error().Err(err).Str("wallet", wallet.PublicKey.ToBase58()).Msg("Failed to get authorization token")
+			continue
+		}
+
+		sess := &tasks.Session{
+			RPCClient:     rpcClient,
+			ConfirmClient: confirmClient,
+			Wallet:        wallet,
+			AuthToken:     authToken,
+		}
+
+		log.Info().Str("wallet", wallet.PublicKey.ToBase58()).Msg("Running tasks")
+		if err := tasks.Run(context.TODO(), sess, loaded); err != nil {
+			log.Error().Err(err).Str("wallet", wallet.PublicKey.ToBase58()).Msg("Task run failed")
+			continue
+		}
+	}
+	return nil
+}
+
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "keystore" {
+		initLogger()
+		if err := runKeystoreCommand(os.Args[2:]); err != nil {
+			log.Error().Err(err).Msg("keystore command failed")
+			os.Exit(1)
+		}
+		return
+	}
+
+	useNonceAccount := flag.Bool("nonce-account", false, "build transfers against a durable nonce account instead of fetching a fresh blockhash per send")
+	dryRun := flag.Bool("dry-run", false, "print the built transfer transactions instead of sending them (built-in flow only: --tasks runs still send, and --nonce-account still creates the nonce account on-chain)")
+	tasksFile := flag.String("tasks", "", "run a YAML/JSON task file instead of the built-in transfer+claim flow")
+	flag.Parse()
+
 	initLogger()
 	rand.Seed(time.Now().UnixNano())
 
-	privateKeys, err := readPrivateKeys("pk.txt")
+	accounts, err := keystore.Load("pk.txt")
 	if err != nil {
 		log.Error().Err(err).Msg("Failed to read private key file")
 		return
 	}
 
+	rpcSonic := "https://devnet.sonic.game"
+	rpcClient := client.NewClient(rpcSonic)
+	confirmClient := sonicrpc.New(rpcClient, sonicWsURL)
+
+	if *tasksFile != "" {
+		if err := runTasks(rpcClient, confirmClient, accounts, *tasksFile); err != nil {
+			log.Error().Err(err).Msg("Failed to run task file")
+		}
+		return
+	}
+
 	fmt.Print("Do you want to use Authorization key for claiming rewards? (y/n): ")
 	reader := bufio.NewReader(os.Stdin)
 	useAuthInput, _ := reader.ReadString('\n')
@@ -281,9 +428,6 @@ func main() {
 
 	useAuth := useAuthInput == "y"
 
-	rpcSonic := "https://devnet.sonic.game"
-	rpcClient := client.NewClient(rpcSonic)
-
 	fmt.Print("How many addresses do you want to generate: ")
 	addressCountInput, _ := reader.ReadString('\n')
 	addressCountInput = strings.TrimSpace(addressCountInput)
@@ -305,18 +449,7 @@ func main() {
 	var wg sync.WaitGroup
 	startTime := time.Now()
 
-	for _, privateKeyBase58 := range privateKeys {
-		accountFrom, err := types.AccountFromBase58(privateKeyBase58)
-		if err != nil {
-			log.Error().Err(err).Msg("Failed to generate keypair")
-			continue
-		}
-
-		if privateKeyBase58 == "" {
-			log.Error().Msg("No private keys found")
-			continue
-		}
-
+	for _, accountFrom := range accounts {
 		balanceResult, err := rpcClient.GetBalance(context.TODO(), accountFrom.PublicKey.ToBase58())
 		if err != nil {
 			log.Error().Err(err).Msg("Failed to get balance")
@@ -334,6 +467,15 @@ func main() {
 			Float64("balance", float64(balance)/1_000_000_000).
 			Msg("Wallet balance")
 
+		var nonceAccount common.PublicKey
+		if *useNonceAccount {
+			nonceAccount, err = ensureNonceAccount(rpcClient, confirmClient, accountFrom)
+			if err != nil {
+				log.Error().Err(err).Msg("Failed to set up nonce account")
+				continue
+			}
+		}
+
 		var addresses []common.PublicKey
 		for i := 0; i < addressCount; i++ {
 			newKeypair := types.NewAccount()
@@ -341,71 +483,77 @@ func main() {
 			fmt.Printf("Generated address %d: %s\n", i+1, newKeypair.PublicKey.ToBase58())
 		}
 
-		for _, address := range addresses {
-			wg.Add(1)
-			go func(address common.PublicKey) {
-				defer wg.Done()
-				for {
-					var blockhashResponse rpc.GetLatestBlockhashValue
-					var err error
-					for {
-						blockhashResponse, err = rpcClient.GetLatestBlockhash(context.TODO())
-						if err == nil {
-							break
-						}
-						log.Error().Msg("Failed to get blockhash, retrying...")
-						time.Sleep(delayRetry)
-					}
-
-					randomAmount := minSolAmount + rand.Float64()*(maxSolAmount-minSolAmount)
-					solAmount := uint64(randomAmount * 1_000_000_000)
-
-					instruction := system.Transfer(system.TransferParam{
-						From:   accountFrom.PublicKey,
-						To:     address,
-						Amount: solAmount,
-					})
-
-					message := types.NewMessage(types.NewMessageParam{
-						FeePayer:        accountFrom.PublicKey,
-						RecentBlockhash: blockhashResponse.Blockhash,
-						Instructions:    []types.Instruction{instruction},
-					})
-
-					tx, err := types.NewTransaction(types.NewTransactionParam{
-						Message: message,
-						Signers: []types.Account{accountFrom},
-					})
-					if err != nil {
-						log.Error().Msg("Failed to create transaction")
-						continue
-					}
-
-					for {
-						txHash, err := rpcClient.SendTransaction(context.TODO(), tx)
-						if err == nil {
-							log.Info().
-								Str("to address", address.ToBase58()).
-								Str("tx hash", txHash).
-								Float64("amount", float64(solAmount)/1_000_000_000).
-								Msg("Successfully sent SOL")
-							break
-						}
-						log.Error().
-							Str("to address", address.ToBase58()).
-							Msg("Failed to send transaction, retrying...")
-						time.Sleep(delayRetry)
-					}
+		sendToAddress := func(address common.PublicKey) {
+			randomAmount := minSolAmount + rand.Float64()*(maxSolAmount-minSolAmount)
+			solAmount := uint64(randomAmount * 1_000_000_000)
+
+			for {
+				transaction, err := tx.BuildTransferTx(context.TODO(), rpcClient, accountFrom, address, solAmount, tx.Options{
+					WritableAccounts: []common.PublicKey{accountFrom.PublicKey, address},
+					NonceAccount:     nonceAccount,
+					NonceAuthority:   accountFrom.PublicKey,
+				})
+				if err != nil {
+					log.Error().Err(err).Msg("Failed to build transaction, retrying...")
+					time.Sleep(delayRetry)
+					continue
+				}
+
+				if *dryRun {
+					fmt.Println(txfmt.EncodeTree(transaction))
 					break
 				}
-				time.Sleep(time.Duration(delay) * time.Second)
-			}(address)
+
+				confirmCtx, cancel := context.WithTimeout(context.Background(), confirmTimeout)
+				result, err := confirmClient.SendAndConfirm(confirmCtx, transaction, sonicrpc.CommitmentConfirmed)
+				cancel()
+				if err != nil {
+					log.Error().
+						Err(err).
+						Str("to address", address.ToBase58()).
+						Msg("Failed to send transaction, retrying...")
+					time.Sleep(delayRetry)
+					continue
+				}
+				if result.Status == sonicrpc.Dropped {
+					log.Error().
+						Str("to address", address.ToBase58()).
+						Msg("Blockhash expired before confirmation, rebuilding transaction...")
+					continue
+				}
+
+				log.Info().
+					Str("to address", address.ToBase58()).
+					Str("tx hash", result.Signature).
+					Str("status", result.Status.String()).
+					Float64("amount", float64(solAmount)/1_000_000_000).
+					Msg("Successfully sent SOL")
+				break
+			}
+			time.Sleep(time.Duration(delay) * time.Second)
 		}
 
-		wg.Wait()
+		if *useNonceAccount {
+			// A durable nonce only backs one valid in-flight transaction at
+			// a time, so fanning these out concurrently would have most
+			// sends race for the same nonce value and get rejected. Send
+			// sequentially instead, advancing the nonce once per send.
+			for _, address := range addresses {
+				sendToAddress(address)
+			}
+		} else {
+			for _, address := range addresses {
+				wg.Add(1)
+				go func(address common.PublicKey) {
+					defer wg.Done()
+					sendToAddress(address)
+				}(address)
+			}
+			wg.Wait()
+		}
 
 		if useAuth {
-			authToken, err := getToken(privateKeyBase58)
+			authToken, err := getToken(accountFrom)
 			if err != nil {
 				log.Error().Err(err).Msg("Failed to get authorization token")
 				continue